@@ -11,6 +11,12 @@ import (
 	"os"
 	"strings"
 	"text/template"
+
+	"github.com/emilien-puget/gomock/internal/backend"
+	"github.com/emilien-puget/gomock/internal/backend/gomock"
+	"github.com/emilien-puget/gomock/internal/backend/testify"
+	"github.com/emilien-puget/gomock/internal/loader"
+	"github.com/emilien-puget/gomock/internal/mockgen"
 )
 
 const mockTemplate = `
@@ -43,18 +49,25 @@ type Mocks struct {
 	Methods  []MethodData
 }
 
-var errMissingResult = errors.New("result is required")
+var (
+	errMissingResult    = errors.New("result is required")
+	errMissingSource    = errors.New("one of -source or -package/-interface is required")
+	errUnknownFramework = errors.New("unknown -framework, want testify or gomock")
+)
 
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Println("Usage: go run main.go <interface>")
-		return
-	}
-
+	source := flag.String("source", "", "path to a Go file containing the interfaces to mock, mockgen -source style")
+	pkgPath := flag.String("package", "", "import path of the package containing the interfaces to mock, used with -interface")
+	interfaceNames := flag.String("interface", "", "comma separated interface names to mock, used with -package")
+	stdin := flag.Bool("stdin", false, "read a bare interface declaration from the positional argument instead of real source")
+	framework := flag.String("framework", "testify", "mocking framework to generate for: testify or gomock, ignored with -stdin")
 	path := flag.String("result", "", "the path of the generated file, not used if stdout is piped")
 	flag.Parse()
 
-	interfaceCode := os.Args[1]
+	if *stdin && flag.NArg() != 1 {
+		fmt.Println("Usage: mock_gen -stdin <interface>")
+		return
+	}
 
 	writer, closer, err := getWriter(path)
 	if err != nil {
@@ -63,13 +76,54 @@ func main() {
 	}
 	defer closer()
 
-	err = interfaceToMock(writer, interfaceCode)
+	if *stdin {
+		err = interfaceToMock(writer, flag.Arg(0))
+	} else {
+		err = generateFromSource(writer, *source, *pkgPath, *interfaceNames, *framework)
+	}
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+func generateFromSource(writer *bufio.Writer, source, pkgPath, interfaceNames, framework string) error {
+	var (
+		ifaces []loader.Interface
+		err    error
+	)
+
+	switch {
+	case source != "":
+		ifaces, err = loader.FromSource(source)
+	case pkgPath != "" && interfaceNames != "":
+		ifaces, err = loader.FromPackage(pkgPath, strings.Split(interfaceNames, ","))
+	default:
+		return errMissingSource
+	}
+	if err != nil {
+		return err
+	}
+
+	be, err := backendFor(framework)
+	if err != nil {
+		return err
+	}
+
+	return mockgen.Generate(writer, ifaces, be)
+}
+
+func backendFor(framework string) (backend.Backend, error) {
+	switch framework {
+	case "testify":
+		return testify.Backend{}, nil
+	case "gomock":
+		return gomock.Backend{}, nil
+	default:
+		return nil, errUnknownFramework
+	}
+}
+
 func getWriter(path *string) (*bufio.Writer, func(), error) {
 	o, _ := os.Stdout.Stat()
 	if (o.Mode() & os.ModeCharDevice) == os.ModeCharDevice {
@@ -149,14 +203,19 @@ func getMethodsFromInterface(interfaceType *ast.InterfaceType, mockName string)
 		paramList := getParameters(method.Type.(*ast.FuncType).Params)
 		returnList := getParameters(method.Type.(*ast.FuncType).Results)
 
+		returnStmt := "return"
+		if returnNames := getReturnNames(returnList); returnNames != "" {
+			returnStmt = "return " + returnNames
+		}
+
 		methodCode := fmt.Sprintf(
-			"func (m *%s) %s(%s) (%s) {\n\targs := m.Called(%s)\n\treturn %s\n}",
+			"func (m *%s) %s(%s) (%s) {\n\targs := m.Called(%s)\n\t%s\n}",
 			mockName,
 			methodName,
 			paramList,
 			returnList,
 			getArgumentNames(paramList),
-			getReturnNames(returnList),
+			returnStmt,
 		)
 
 		methods = append(methods, MethodData{
@@ -184,6 +243,9 @@ func getParameters(fieldList *ast.FieldList) string {
 }
 
 func getArgumentNames(paramList string) string {
+	if paramList == "" {
+		return ""
+	}
 	params := strings.Split(paramList, ", ")
 	var argNames []string
 	for _, param := range params {
@@ -202,7 +264,7 @@ func getTypeName(expr ast.Expr) string {
 		sel := t.Sel.Name
 		return fmt.Sprintf("%s.%s", x.Name, sel)
 	case *ast.StarExpr:
-		return "" + getTypeName(t.X)
+		return "*" + getTypeName(t.X)
 	case *ast.ArrayType:
 		return "[]" + getTypeName(t.Elt)
 	case *ast.MapType:
@@ -219,6 +281,9 @@ func getTypeName(expr ast.Expr) string {
 }
 
 func getReturnNames(returnList string) string {
+	if returnList == "" {
+		return ""
+	}
 	returnNames := strings.Split(returnList, ", ")
 	var names []string
 	for i := 0; i < len(returnNames); i++ {
@@ -167,6 +167,69 @@ func (m *biduleMock) Method2(arg1 int, arg2 int) (ret1 foo.Bar, err error) {
 	args := m.Called(arg1, arg2)
 	return args.Get(0).(foo.Bar), args.Get(1).(error)
 }
+`,
+		},
+		"no_args": {
+			interfaceCode: `type bidule interface {
+Method1() (string, error)
+}`,
+			expected: `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type biduleMock struct {
+	mock.Mock
+}
+
+func (m *biduleMock) Method1() (string, error) {
+	args := m.Called()
+	return args.Get(0).(string), args.Get(1).(error)
+}
+`,
+		},
+		"no_return": {
+			interfaceCode: `type bidule interface {
+Method1(arg1 int)
+}`,
+			expected: `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type biduleMock struct {
+	mock.Mock
+}
+
+func (m *biduleMock) Method1(arg1 int) () {
+	args := m.Called(arg1)
+	return
+}
+`,
+		},
+		"pointer": {
+			interfaceCode: `type bidule interface {
+Method1(arg1 *string) *foo.Bar
+}`,
+			expected: `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type biduleMock struct {
+	mock.Mock
+}
+
+func (m *biduleMock) Method1(arg1 *string) (*foo.Bar) {
+	args := m.Called(arg1)
+	return args.Get(0).(*foo.Bar)
+}
 `,
 		},
 	}
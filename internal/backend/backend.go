@@ -0,0 +1,44 @@
+// Package backend defines the framework-agnostic interface description
+// that mockgen builds once per run, and the Backend contract that turns
+// it into a complete mock file for one mocking framework.
+package backend
+
+import "io"
+
+// Interface is a mock to generate: its name and the methods that need
+// an implementation, already rendered to Go source types by the caller.
+// TypeParamDecl and TypeParamNames are empty for non-generic
+// interfaces; otherwise they hold the declaration ("[K comparable, V
+// any]") and the bare reference ("[K, V]") a backend splices into the
+// mock's type declarations, constructors, and receivers.
+type Interface struct {
+	Name           string
+	Methods        []Method
+	TypeParamDecl  string
+	TypeParamNames string
+}
+
+// Method is a single method of Interface, with its parameters and
+// results already rendered to Go source types.
+type Method struct {
+	Name     string
+	Receiver string
+	Params   []Param
+	Results  []Param
+	Variadic bool
+}
+
+// Param is one parameter or result: Name is empty for results, which
+// are never referenced by name.
+type Param struct {
+	Name string
+	Type string
+}
+
+// Backend renders a set of interfaces into a complete mock file for one
+// mocking framework. imports are the additional import lines collected
+// from the interfaces' own types; the backend is responsible for adding
+// whatever framework import it needs on top of those.
+type Backend interface {
+	Generate(w io.Writer, ifaces []Interface, imports []string) error
+}
@@ -0,0 +1,201 @@
+// Package gomock renders mocks as go.uber.org/mock/gomock controller +
+// recorder pairs, matching the shape produced by mockgen itself.
+package gomock
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/emilien-puget/gomock/internal/backend"
+)
+
+const tmplSrc = `
+package mocks
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+{{- range .Imports }}
+	{{ . }}
+{{- end }}
+)
+{{ range .Mocks }}
+type {{ .Name }}{{ .TypeParamDecl }} struct {
+	ctrl     *gomock.Controller
+	recorder *{{ .Name }}MockRecorder{{ .TypeParamNames }}
+}
+
+type {{ .Name }}MockRecorder{{ .TypeParamDecl }} struct {
+	mock *{{ .Name }}{{ .TypeParamNames }}
+}
+
+func New{{ .Name }}{{ .TypeParamDecl }}(ctrl *gomock.Controller) *{{ .Name }}{{ .TypeParamNames }} {
+	mock := &{{ .Name }}{{ .TypeParamNames }}{ctrl: ctrl}
+	mock.recorder = &{{ .Name }}MockRecorder{{ .TypeParamNames }}{mock: mock}
+	return mock
+}
+
+func (m *{{ .Name }}{{ .TypeParamNames }}) EXPECT() *{{ .Name }}MockRecorder{{ .TypeParamNames }} {
+	return m.recorder
+}
+{{- range .Methods }}
+
+{{ .ImplCode }}
+
+{{ .RecorderCode }}
+{{- end }}
+{{ end -}}
+`
+
+type mockView struct {
+	Name           string
+	TypeParamDecl  string
+	TypeParamNames string
+	Methods        []methodView
+}
+
+type methodView struct {
+	ImplCode     string
+	RecorderCode string
+}
+
+type templateData struct {
+	Imports []string
+	Mocks   []mockView
+}
+
+// Backend renders each interface as a MockFoo/MockFooMockRecorder pair,
+// the same shape golang/mock's own mockgen produces.
+type Backend struct{}
+
+func (Backend) Generate(w io.Writer, ifaces []backend.Interface, imports []string) error {
+	data := templateData{Imports: imports}
+
+	for _, iface := range ifaces {
+		name := "Mock" + iface.Name
+		mock := mockView{Name: name, TypeParamDecl: iface.TypeParamDecl, TypeParamNames: iface.TypeParamNames}
+		for _, method := range iface.Methods {
+			mock.Methods = append(mock.Methods, methodView{
+				ImplCode:     implCode(name, iface.TypeParamNames, method),
+				RecorderCode: recorderCode(name, iface.TypeParamNames, method),
+			})
+		}
+		data.Mocks = append(data.Mocks, mock)
+	}
+
+	tmpl, err := template.New("mock").Parse(tmplSrc)
+	if err != nil {
+		return fmt.Errorf("template.New: %w", err)
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("tmpl.Execute: %w", err)
+	}
+
+	return nil
+}
+
+func implCode(mockName, typeParamNames string, method backend.Method) string {
+	paramDecls := make([]string, len(method.Params))
+	argNames := make([]string, len(method.Params))
+	for i, p := range method.Params {
+		argNames[i] = p.Name
+		if method.Variadic && i == len(method.Params)-1 {
+			paramDecls[i] = fmt.Sprintf("%s ...%s", p.Name, p.Type)
+			continue
+		}
+		paramDecls[i] = fmt.Sprintf("%s %s", p.Name, p.Type)
+	}
+
+	resultDecls := make([]string, len(method.Results))
+	for i, r := range method.Results {
+		resultDecls[i] = r.Type
+	}
+
+	recv := method.Receiver
+	if recv == "" {
+		recv = "m"
+	}
+
+	var body strings.Builder
+
+	callExpr := fmt.Sprintf("%s.ctrl.Call(%s, %q%s)", recv, recv, method.Name, withLeadingComma(strings.Join(argNames, ", ")))
+	if method.Variadic && len(argNames) > 0 {
+		fixed := argNames[:len(argNames)-1]
+		fmt.Fprintf(&body, "varargs := []interface{}{%s}\n\tfor _, a := range %s {\n\t\tvarargs = append(varargs, a)\n\t}\n\t", strings.Join(fixed, ", "), argNames[len(argNames)-1])
+		callExpr = fmt.Sprintf("%s.ctrl.Call(%s, %q, varargs...)", recv, recv, method.Name)
+	}
+
+	if len(method.Results) == 0 {
+		body.WriteString(callExpr)
+	} else {
+		fmt.Fprintf(&body, "ret := %s", callExpr)
+		retNames := make([]string, len(method.Results))
+		for i, r := range method.Results {
+			retNames[i] = fmt.Sprintf("ret%d", i)
+			fmt.Fprintf(&body, "\n\t%s, _ := ret[%d].(%s)", retNames[i], i, r.Type)
+		}
+		fmt.Fprintf(&body, "\n\treturn %s", strings.Join(retNames, ", "))
+	}
+
+	return fmt.Sprintf("func (%s *%s%s) %s(%s) (%s) {\n\t%s\n}", recv, mockName, typeParamNames, method.Name, strings.Join(paramDecls, ", "), strings.Join(resultDecls, ", "), body.String())
+}
+
+func recorderCode(mockName, typeParamNames string, method backend.Method) string {
+	argNames := make([]string, len(method.Params))
+	for i, p := range method.Params {
+		argNames[i] = p.Name
+	}
+
+	recorderParams := recorderParamDecls(argNames, method.Variadic)
+	recorderName := mockName + "MockRecorder" + typeParamNames
+	methodType := fmt.Sprintf("reflect.TypeOf((*%s%s)(nil).%s)", mockName, typeParamNames, method.Name)
+
+	var body strings.Builder
+
+	callArgs := strings.Join(argNames, ", ")
+	if method.Variadic && len(argNames) > 0 {
+		fixed := argNames[:len(argNames)-1]
+		fmt.Fprintf(&body, "varargs := append([]any{%s}, %s...)\n\t", strings.Join(fixed, ", "), argNames[len(argNames)-1])
+		callArgs = "varargs..."
+	}
+
+	fmt.Fprintf(&body, "return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, %q, %s%s)", method.Name, methodType, withLeadingComma(callArgs))
+
+	return fmt.Sprintf("func (mr *%s) %s(%s) *gomock.Call {\n\t%s\n}", recorderName, method.Name, recorderParams, body.String())
+}
+
+// recorderParamDecls renders a recorder's parameter list. Every
+// parameter is typed `any` so matchers can be passed in place of a
+// concrete value, and consecutive fixed parameters share one `any`
+// the way gofmt would group identically typed parameters.
+func recorderParamDecls(argNames []string, variadic bool) string {
+	if len(argNames) == 0 {
+		return ""
+	}
+
+	if !variadic {
+		return strings.Join(argNames, ", ") + " any"
+	}
+
+	fixed := argNames[:len(argNames)-1]
+	last := argNames[len(argNames)-1] + " ...any"
+	if len(fixed) == 0 {
+		return last
+	}
+
+	return strings.Join(fixed, ", ") + " any, " + last
+}
+
+// withLeadingComma prefixes s with ", " unless it is empty, so call
+// argument lists render correctly for zero-argument methods.
+func withLeadingComma(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return ", " + s
+}
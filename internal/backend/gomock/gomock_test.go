@@ -0,0 +1,281 @@
+package gomock_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/emilien-puget/gomock/internal/backend"
+	"github.com/emilien-puget/gomock/internal/backend/gomock"
+)
+
+func Test_Backend_Generate(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name: "bidule",
+			Methods: []backend.Method{
+				{
+					Name:    "Method1",
+					Params:  []backend.Param{{Name: "arg1", Type: "string"}, {Name: "arg2", Type: "int"}},
+					Results: []backend.Param{{Type: "string"}, {Type: "error"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type Mockbidule struct {
+	ctrl     *gomock.Controller
+	recorder *MockbiduleMockRecorder
+}
+
+type MockbiduleMockRecorder struct {
+	mock *Mockbidule
+}
+
+func NewMockbidule(ctrl *gomock.Controller) *Mockbidule {
+	mock := &Mockbidule{ctrl: ctrl}
+	mock.recorder = &MockbiduleMockRecorder{mock: mock}
+	return mock
+}
+
+func (m *Mockbidule) EXPECT() *MockbiduleMockRecorder {
+	return m.recorder
+}
+
+func (m *Mockbidule) Method1(arg1 string, arg2 int) (string, error) {
+	ret := m.ctrl.Call(m, "Method1", arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockbiduleMockRecorder) Method1(arg1, arg2 any) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method1", reflect.TypeOf((*Mockbidule)(nil).Method1), arg1, arg2)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (gomock.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_Backend_Generate_variadic(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name: "bidule",
+			Methods: []backend.Method{
+				{
+					Name:     "Method1",
+					Variadic: true,
+					Params:   []backend.Param{{Name: "arg1", Type: "string"}, {Name: "extra", Type: "int"}},
+					Results:  []backend.Param{{Type: "int"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type Mockbidule struct {
+	ctrl     *gomock.Controller
+	recorder *MockbiduleMockRecorder
+}
+
+type MockbiduleMockRecorder struct {
+	mock *Mockbidule
+}
+
+func NewMockbidule(ctrl *gomock.Controller) *Mockbidule {
+	mock := &Mockbidule{ctrl: ctrl}
+	mock.recorder = &MockbiduleMockRecorder{mock: mock}
+	return mock
+}
+
+func (m *Mockbidule) EXPECT() *MockbiduleMockRecorder {
+	return m.recorder
+}
+
+func (m *Mockbidule) Method1(arg1 string, extra ...int) (int) {
+	varargs := []interface{}{arg1}
+	for _, a := range extra {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Method1", varargs...)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+func (mr *MockbiduleMockRecorder) Method1(arg1 any, extra ...any) *gomock.Call {
+	varargs := append([]any{arg1}, extra...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method1", reflect.TypeOf((*Mockbidule)(nil).Method1), varargs...)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (gomock.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_Backend_Generate_generic_variadic(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name:           "store",
+			TypeParamDecl:  "[K comparable, V any]",
+			TypeParamNames: "[K, V]",
+			Methods: []backend.Method{
+				{
+					Name:     "Keys",
+					Variadic: true,
+					Params:   []backend.Param{{Name: "extra", Type: "K"}},
+					Results:  []backend.Param{{Type: "[]K"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type Mockstore[K comparable, V any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMockRecorder[K, V]
+}
+
+type MockstoreMockRecorder[K comparable, V any] struct {
+	mock *Mockstore[K, V]
+}
+
+func NewMockstore[K comparable, V any](ctrl *gomock.Controller) *Mockstore[K, V] {
+	mock := &Mockstore[K, V]{ctrl: ctrl}
+	mock.recorder = &MockstoreMockRecorder[K, V]{mock: mock}
+	return mock
+}
+
+func (m *Mockstore[K, V]) EXPECT() *MockstoreMockRecorder[K, V] {
+	return m.recorder
+}
+
+func (m *Mockstore[K, V]) Keys(extra ...K) ([]K) {
+	varargs := []interface{}{}
+	for _, a := range extra {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Keys", varargs...)
+	ret0, _ := ret[0].([]K)
+	return ret0
+}
+
+func (mr *MockstoreMockRecorder[K, V]) Keys(extra ...any) *gomock.Call {
+	varargs := append([]any{}, extra...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*Mockstore[K, V])(nil).Keys), varargs...)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (gomock.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_Backend_Generate_generic(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name:           "store",
+			TypeParamDecl:  "[K comparable, V any]",
+			TypeParamNames: "[K, V]",
+			Methods: []backend.Method{
+				{
+					Name:    "Get",
+					Params:  []backend.Param{{Name: "key", Type: "K"}},
+					Results: []backend.Param{{Type: "V"}, {Type: "error"}},
+				},
+				{
+					Name:    "Set",
+					Params:  []backend.Param{{Name: "ctx", Type: "context.Context"}, {Name: "key", Type: "K"}, {Name: "value", Type: "V"}},
+					Results: []backend.Param{{Type: "error"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+type Mockstore[K comparable, V any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMockRecorder[K, V]
+}
+
+type MockstoreMockRecorder[K comparable, V any] struct {
+	mock *Mockstore[K, V]
+}
+
+func NewMockstore[K comparable, V any](ctrl *gomock.Controller) *Mockstore[K, V] {
+	mock := &Mockstore[K, V]{ctrl: ctrl}
+	mock.recorder = &MockstoreMockRecorder[K, V]{mock: mock}
+	return mock
+}
+
+func (m *Mockstore[K, V]) EXPECT() *MockstoreMockRecorder[K, V] {
+	return m.recorder
+}
+
+func (m *Mockstore[K, V]) Get(key K) (V, error) {
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(V)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockstoreMockRecorder[K, V]) Get(key any) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockstore[K, V])(nil).Get), key)
+}
+
+func (m *Mockstore[K, V]) Set(ctx context.Context, key K, value V) (error) {
+	ret := m.ctrl.Call(m, "Set", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockstoreMockRecorder[K, V]) Set(ctx, key, value any) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*Mockstore[K, V])(nil).Set), ctx, key, value)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (gomock.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
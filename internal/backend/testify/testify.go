@@ -0,0 +1,116 @@
+// Package testify renders mocks as github.com/stretchr/testify/mock
+// based structs, matching the template this generator has always used.
+package testify
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/emilien-puget/gomock/internal/backend"
+)
+
+const tmplSrc = `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+{{- range .Imports }}
+	{{ . }}
+{{- end }}
+)
+{{ range .Mocks }}
+type {{ .Name }}{{ .TypeParamDecl }} struct {
+	mock.Mock
+}
+{{- range .Methods }}
+
+{{ .Code }}
+{{- end }}
+{{ end -}}
+`
+
+type mockView struct {
+	Name          string
+	TypeParamDecl string
+	Methods       []methodView
+}
+
+type methodView struct {
+	Code string
+}
+
+type templateData struct {
+	Imports []string
+	Mocks   []mockView
+}
+
+// Backend renders each interface as a `mock.Mock`-embedding struct with
+// one method implementation per interface method.
+type Backend struct{}
+
+func (Backend) Generate(w io.Writer, ifaces []backend.Interface, imports []string) error {
+	data := templateData{Imports: imports}
+
+	for _, iface := range ifaces {
+		name := iface.Name + "Mock"
+		mock := mockView{Name: name, TypeParamDecl: iface.TypeParamDecl}
+		for _, method := range iface.Methods {
+			mock.Methods = append(mock.Methods, methodView{Code: methodCode(name+iface.TypeParamNames, method)})
+		}
+		data.Mocks = append(data.Mocks, mock)
+	}
+
+	tmpl, err := template.New("mock").Parse(tmplSrc)
+	if err != nil {
+		return fmt.Errorf("template.New: %w", err)
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("tmpl.Execute: %w", err)
+	}
+
+	return nil
+}
+
+func methodCode(mockName string, method backend.Method) string {
+	paramDecls := make([]string, len(method.Params))
+	argNames := make([]string, len(method.Params))
+	for i, p := range method.Params {
+		argNames[i] = p.Name
+		if method.Variadic && i == len(method.Params)-1 {
+			paramDecls[i] = fmt.Sprintf("%s ...%s", p.Name, p.Type)
+			continue
+		}
+		paramDecls[i] = fmt.Sprintf("%s %s", p.Name, p.Type)
+	}
+
+	resultDecls := make([]string, len(method.Results))
+	returnExprs := make([]string, len(method.Results))
+	for i, r := range method.Results {
+		resultDecls[i] = r.Type
+		returnExprs[i] = fmt.Sprintf("args.Get(%d).(%s)", i, r.Type)
+	}
+
+	recv := method.Receiver
+	if recv == "" {
+		recv = "m"
+	}
+
+	var preamble string
+	callArgs := strings.Join(argNames, ", ")
+	if method.Variadic && len(argNames) > 0 {
+		fixed := argNames[:len(argNames)-1]
+		last := argNames[len(argNames)-1]
+		preamble = fmt.Sprintf("varargs := []interface{}{%s}\n\tfor _, a := range %s {\n\t\tvarargs = append(varargs, a)\n\t}\n\t", strings.Join(fixed, ", "), last)
+		callArgs = "varargs..."
+	}
+
+	body := fmt.Sprintf("%sargs := %s.Called(%s)\n\treturn %s", preamble, recv, callArgs, strings.Join(returnExprs, ", "))
+	if len(method.Results) == 0 {
+		body = fmt.Sprintf("%s%s.Called(%s)", preamble, recv, callArgs)
+	}
+
+	return fmt.Sprintf("func (%s *%s) %s(%s) (%s) {\n\t%s\n}", recv, mockName, method.Name, strings.Join(paramDecls, ", "), strings.Join(resultDecls, ", "), body)
+}
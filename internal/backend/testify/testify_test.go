@@ -0,0 +1,142 @@
+package testify_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/emilien-puget/gomock/internal/backend"
+	"github.com/emilien-puget/gomock/internal/backend/testify"
+)
+
+func Test_Backend_Generate(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name: "bidule",
+			Methods: []backend.Method{
+				{
+					Name:    "Method1",
+					Params:  []backend.Param{{Name: "arg1", Type: "string"}, {Name: "arg2", Type: "int"}},
+					Results: []backend.Param{{Type: "string"}, {Type: "error"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type biduleMock struct {
+	mock.Mock
+}
+
+func (m *biduleMock) Method1(arg1 string, arg2 int) (string, error) {
+	args := m.Called(arg1, arg2)
+	return args.Get(0).(string), args.Get(1).(error)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (testify.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_Backend_Generate_generic_variadic(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name:           "store",
+			TypeParamDecl:  "[K comparable, V any]",
+			TypeParamNames: "[K, V]",
+			Methods: []backend.Method{
+				{
+					Name:     "Keys",
+					Variadic: true,
+					Params:   []backend.Param{{Name: "extra", Type: "K"}},
+					Results:  []backend.Param{{Type: "[]K"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type storeMock[K comparable, V any] struct {
+	mock.Mock
+}
+
+func (m *storeMock[K, V]) Keys(extra ...K) ([]K) {
+	varargs := []interface{}{}
+	for _, a := range extra {
+		varargs = append(varargs, a)
+	}
+	args := m.Called(varargs...)
+	return args.Get(0).([]K)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (testify.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_Backend_Generate_generic(t *testing.T) {
+	ifaces := []backend.Interface{
+		{
+			Name:           "store",
+			TypeParamDecl:  "[K comparable, V any]",
+			TypeParamNames: "[K, V]",
+			Methods: []backend.Method{
+				{
+					Name:    "Get",
+					Params:  []backend.Param{{Name: "key", Type: "K"}},
+					Results: []backend.Param{{Type: "V"}, {Type: "error"}},
+				},
+				{
+					Name:    "Set",
+					Params:  []backend.Param{{Name: "ctx", Type: "context.Context"}, {Name: "key", Type: "K"}, {Name: "value", Type: "V"}},
+					Results: []backend.Param{{Type: "error"}},
+				},
+			},
+		},
+	}
+
+	expected := `
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type storeMock[K comparable, V any] struct {
+	mock.Mock
+}
+
+func (m *storeMock[K, V]) Get(key K) (V, error) {
+	args := m.Called(key)
+	return args.Get(0).(V), args.Get(1).(error)
+}
+
+func (m *storeMock[K, V]) Set(ctx context.Context, key K, value V) (error) {
+	args := m.Called(ctx, key, value)
+	return args.Get(0).(error)
+}
+`
+
+	buf := &bytes.Buffer{}
+	err := (testify.Backend{}).Generate(buf, ifaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,125 @@
+// Package loader resolves the interfaces a mock should be generated
+// for by loading real Go source through golang.org/x/tools/go/packages,
+// instead of parsing a bare interface snippet.
+package loader
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Interface is a single interface type discovered while loading a
+// package, resolved to its complete go/types representation (embedded
+// interfaces are already flattened into the method set). TypeParams is
+// nil for non-generic interfaces.
+type Interface struct {
+	Name       string
+	Type       *types.Interface
+	TypeParams *types.TypeParamList
+}
+
+const loadMode = packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedImports
+
+// FromSource loads every interface declared in file, mockgen "-source"
+// style: the whole file is mocked, not just a named subset of it.
+func FromSource(file string) ([]Interface, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, "file="+file)
+	if err != nil {
+		return nil, fmt.Errorf("packages.Load: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no package found for %s", file)
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("loading %s: %w", file, pkg.Errors[0])
+	}
+
+	wantFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", file, err)
+	}
+
+	var ifaces []Interface
+	for _, f := range pkg.Syntax {
+		fileName, err := filepath.Abs(pkg.Fset.Position(f.Pos()).Filename)
+		if err != nil || fileName != wantFile {
+			continue
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); !ok {
+				return true
+			}
+
+			obj := pkg.TypesInfo.Defs[typeSpec.Name]
+			if obj == nil {
+				return true
+			}
+
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				return true
+			}
+
+			iface, ok := named.Underlying().(*types.Interface)
+			if !ok {
+				return true
+			}
+
+			ifaces = append(ifaces, Interface{Name: typeSpec.Name.Name, Type: iface.Complete(), TypeParams: named.TypeParams()})
+
+			return true
+		})
+	}
+
+	return ifaces, nil
+}
+
+// FromPackage loads pkgPath and resolves each named interface in names,
+// mockgen "-package/-interface" style.
+func FromPackage(pkgPath string, names []string) ([]Interface, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("packages.Load: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("package not found: %s", pkgPath)
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("loading %s: %w", pkgPath, pkg.Errors[0])
+	}
+
+	ifaces := make([]Interface, 0, len(names))
+	for _, name := range names {
+		obj := pkg.Types.Scope().Lookup(name)
+		if obj == nil {
+			return nil, fmt.Errorf("interface %s not found in %s", name, pkgPath)
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s is not an interface", pkgPath, name)
+		}
+
+		iface, ok := named.Underlying().(*types.Interface)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s is not an interface", pkgPath, name)
+		}
+
+		ifaces = append(ifaces, Interface{Name: name, Type: iface.Complete(), TypeParams: named.TypeParams()})
+	}
+
+	return ifaces, nil
+}
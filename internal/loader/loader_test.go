@@ -0,0 +1,43 @@
+package loader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/emilien-puget/gomock/internal/loader"
+)
+
+func Test_FromSource_OnlyRequestedFile(t *testing.T) {
+	ifaces, err := loader.FromSource("testdata/gensample/embed.go")
+	require.NoError(t, err)
+
+	var names []string
+	for _, iface := range ifaces {
+		names = append(names, iface.Name)
+	}
+
+	assert.Equal(t, []string{"ReadCloser"}, names)
+}
+
+const gensamplePkg = "github.com/emilien-puget/gomock/internal/loader/testdata/gensample"
+
+func Test_FromPackage(t *testing.T) {
+	ifaces, err := loader.FromPackage(gensamplePkg, []string{"Store", "Logger"})
+	require.NoError(t, err)
+	require.Len(t, ifaces, 2)
+	assert.Equal(t, "Store", ifaces[0].Name)
+	assert.Equal(t, "Logger", ifaces[1].Name)
+}
+
+func Test_FromPackage_UnknownPackage(t *testing.T) {
+	_, err := loader.FromPackage("github.com/emilien-puget/gomock/internal/loader/testdata/doesnotexist", []string{"Store"})
+	require.Error(t, err)
+}
+
+func Test_FromPackage_UnknownInterface(t *testing.T) {
+	_, err := loader.FromPackage(gensamplePkg, []string{"DoesNotExist"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DoesNotExist")
+}
@@ -0,0 +1,7 @@
+package gensample
+
+// ReadCloser reads and closes a resource.
+type ReadCloser interface {
+	Read(p []byte) (n int, err error)
+	Close() error
+}
@@ -0,0 +1,6 @@
+package gensample
+
+// Logger logs messages.
+type Logger interface {
+	Log(message string)
+}
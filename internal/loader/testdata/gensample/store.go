@@ -0,0 +1,7 @@
+package gensample
+
+// Store is a key/value store.
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+}
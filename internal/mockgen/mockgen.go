@@ -0,0 +1,109 @@
+// Package mockgen resolves interfaces loaded by the loader package into
+// a framework-agnostic description and hands it to a backend.Backend to
+// render as Go source.
+package mockgen
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"strings"
+
+	"github.com/emilien-puget/gomock/internal/backend"
+	"github.com/emilien-puget/gomock/internal/loader"
+	"github.com/emilien-puget/gomock/internal/registry"
+	"github.com/emilien-puget/gomock/internal/render"
+)
+
+// Generate renders mocks for ifaces to writer using be, collecting a
+// single registry shared across every referenced type so import
+// aliases stay consistent across the whole file. It walks the
+// interfaces twice: a first pass lets the registry settle every import
+// alias, including retroactively qualifying a package that only turns
+// out to collide with another once that other is seen; the second
+// pass does the real rendering against the now-settled aliases.
+func Generate(writer io.Writer, ifaces []loader.Interface, be backend.Backend) error {
+	reg := registry.New()
+
+	for _, iface := range ifaces {
+		buildInterface(iface, reg)
+	}
+
+	data := make([]backend.Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		data = append(data, buildInterface(iface, reg))
+	}
+
+	if err := be.Generate(writer, data, reg.Lines()); err != nil {
+		return fmt.Errorf("be.Generate: %w", err)
+	}
+
+	return nil
+}
+
+func buildInterface(iface loader.Interface, reg *registry.Registry) backend.Interface {
+	out := backend.Interface{Name: iface.Name}
+	out.TypeParamDecl, out.TypeParamNames = typeParamStrings(iface.TypeParams, reg)
+
+	for i := 0; i < iface.Type.NumMethods(); i++ {
+		method := iface.Type.Method(i)
+		sig, _ := method.Type().(*types.Signature)
+		out.Methods = append(out.Methods, buildMethod(method.Name(), sig, reg))
+	}
+
+	return out
+}
+
+// typeParamStrings renders a generic interface's type parameter list as
+// its declaration ("[K comparable, V any]") and its bare reference
+// ("[K, V]"), both empty for a non-generic interface.
+func typeParamStrings(tp *types.TypeParamList, reg *registry.Registry) (decl, names string) {
+	if tp.Len() == 0 {
+		return "", ""
+	}
+
+	declParts := make([]string, tp.Len())
+	nameParts := make([]string, tp.Len())
+	for i := 0; i < tp.Len(); i++ {
+		p := tp.At(i)
+		declParts[i] = fmt.Sprintf("%s %s", p.Obj().Name(), render.TypeString(p.Constraint(), reg))
+		nameParts[i] = p.Obj().Name()
+	}
+
+	return "[" + strings.Join(declParts, ", ") + "]", "[" + strings.Join(nameParts, ", ") + "]"
+}
+
+func buildMethod(name string, sig *types.Signature, reg *registry.Registry) backend.Method {
+	scope := reg.NewMethodScope()
+	out := backend.Method{Name: name, Variadic: sig.Variadic()}
+
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		v := params.At(i)
+		typ := v.Type()
+
+		if sig.Variadic() && i == params.Len()-1 {
+			if sl, ok := typ.(*types.Slice); ok {
+				out.Params = append(out.Params, backend.Param{
+					Name: scope.Param(v.Name(), typ),
+					Type: render.TypeString(sl.Elem(), reg),
+				})
+				continue
+			}
+		}
+
+		out.Params = append(out.Params, backend.Param{
+			Name: scope.Param(v.Name(), typ),
+			Type: render.TypeString(typ, reg),
+		})
+	}
+
+	out.Receiver = scope.Receiver("m")
+
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		out.Results = append(out.Results, backend.Param{Type: render.TypeString(results.At(i).Type(), reg)})
+	}
+
+	return out
+}
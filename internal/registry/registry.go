@@ -0,0 +1,290 @@
+// Package registry disambiguates the identifiers a generated mock file
+// needs: non-colliding import aliases for the file as a whole, and
+// non-colliding parameter and receiver names for each method, derived
+// from the parameter's type when the source interface left it unnamed.
+package registry
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+	"unicode"
+)
+
+// Registry tracks the import aliases used by a single generated mock
+// file. Use NewMethodScope for the parameter and receiver names of each
+// individual method.
+type Registry struct {
+	aliasByPath   map[string]string
+	nameByPath    map[string]string
+	usedAlias     map[string]bool
+	bareNameOwner map[string]string
+	order         []string
+}
+
+// New returns an empty Registry.
+func New() *Registry {
+	return &Registry{
+		aliasByPath:   make(map[string]string),
+		nameByPath:    make(map[string]string),
+		usedAlias:     make(map[string]bool),
+		bareNameOwner: make(map[string]string),
+	}
+}
+
+// Alias returns the identifier to use when qualifying a type from pkg,
+// assigning a non-colliding one the first time pkg is seen. Packages
+// that share a base name (k8s.io/api/core/v1 and k8s.io/api/apps/v1,
+// both named "v1") are disambiguated using their parent directory
+// ("corev1", "appsv1") before falling back to a numeric suffix; once a
+// second package collides with an already-bare package, the earlier
+// one is retroactively qualified too, so a caller must re-query Alias
+// for a package it saw before the collision to get its final alias.
+// mockgen.Generate does this by walking every interface twice: once to
+// let the registry settle, once to render using the settled aliases.
+func (r *Registry) Alias(pkg *types.Package) string {
+	if pkg == nil {
+		return ""
+	}
+
+	path := pkg.Path()
+	if alias, ok := r.aliasByPath[path]; ok {
+		return alias
+	}
+
+	alias := r.assignAlias(pkg)
+
+	r.aliasByPath[path] = alias
+	r.nameByPath[path] = pkg.Name()
+	r.usedAlias[alias] = true
+	r.order = append(r.order, path)
+
+	return alias
+}
+
+func (r *Registry) assignAlias(pkg *types.Package) string {
+	name := pkg.Name()
+
+	ownerPath, collides := r.bareNameOwner[name]
+	if !collides {
+		if r.usedAlias[name] {
+			return r.qualifiedAlias(pkg)
+		}
+		r.bareNameOwner[name] = pkg.Path()
+		return name
+	}
+
+	delete(r.bareNameOwner, name)
+	r.requalify(ownerPath)
+
+	return r.qualifiedAlias(pkg)
+}
+
+// requalify moves the package at path off the bare alias it was
+// holding, onto a parent-directory or numeric one, now that a later
+// package has been found to collide with it.
+func (r *Registry) requalify(path string) {
+	old := r.aliasByPath[path]
+
+	qualified := parentDirAlias(path)
+	if qualified == "" || r.usedAlias[qualified] {
+		qualified = r.numericAlias(old)
+	}
+
+	r.aliasByPath[path] = qualified
+	r.usedAlias[qualified] = true
+}
+
+func (r *Registry) qualifiedAlias(pkg *types.Package) string {
+	if candidate := parentDirAlias(pkg.Path()); candidate != "" && !r.usedAlias[candidate] {
+		return candidate
+	}
+
+	return r.numericAlias(pkg.Name())
+}
+
+func (r *Registry) numericAlias(base string) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", base, i)
+		if !r.usedAlias[candidate] {
+			return candidate
+		}
+	}
+}
+
+// parentDirAlias renders path's last two segments as a single
+// identifier ("k8s.io/api/core/v1" -> "corev1"), or "" if path doesn't
+// have a parent directory to borrow from.
+func parentDirAlias(path string) string {
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 {
+		return ""
+	}
+
+	alias := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, segments[len(segments)-2]+segments[len(segments)-1])
+	if alias == "" || unicode.IsDigit([]rune(alias)[0]) {
+		return ""
+	}
+
+	return alias
+}
+
+// Lines renders one import-block entry per referenced package, in the
+// order they were first seen, aliasing only where the alias differs
+// from the package's own name.
+func (r *Registry) Lines() []string {
+	lines := make([]string, 0, len(r.order))
+	for _, path := range r.order {
+		alias := r.aliasByPath[path]
+		if alias == r.nameByPath[path] {
+			lines = append(lines, fmt.Sprintf("%q", path))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %q", alias, path))
+	}
+
+	return lines
+}
+
+// NewMethodScope returns a fresh scope for disambiguating the parameter
+// and receiver names of a single method. Scopes never share state: a
+// name reused across methods is not a collision.
+func (r *Registry) NewMethodScope() *MethodScope {
+	return &MethodScope{used: make(map[string]bool)}
+}
+
+// MethodScope disambiguates the identifiers of a single method.
+type MethodScope struct {
+	used map[string]bool
+}
+
+// Param returns the identifier to use for a parameter named explicit in
+// the source interface, or, if explicit is empty, one derived from typ.
+// A numeric suffix is appended to break ties against earlier parameters
+// in the same scope.
+func (s *MethodScope) Param(explicit string, typ types.Type) string {
+	base := explicit
+	if base == "" {
+		base = deriveParamName(typ)
+	}
+
+	return s.reserve(base)
+}
+
+// Receiver returns preferred, unless it collides with a parameter name
+// already reserved in this scope, in which case a non-colliding
+// alternative is returned instead.
+func (s *MethodScope) Receiver(preferred string) string {
+	if !s.used[preferred] {
+		s.used[preferred] = true
+		return preferred
+	}
+
+	candidate := preferred + "Receiver"
+	for i := 2; s.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", preferred, i)
+	}
+
+	s.used[candidate] = true
+
+	return candidate
+}
+
+func (s *MethodScope) reserve(base string) string {
+	if !s.used[base] {
+		s.used[base] = true
+		return base
+	}
+
+	candidate := base
+	for i := 2; s.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", base, i)
+	}
+
+	s.used[candidate] = true
+
+	return candidate
+}
+
+// deriveParamName picks a short, readable identifier for an unnamed
+// parameter from its type: string -> s, int -> n, []MyType -> myTypes,
+// map[string]int -> stringToInt, chan int -> intCh, *Foo -> fooPtr.
+func deriveParamName(t types.Type) string {
+	switch t := t.(type) {
+	case *types.Basic:
+		switch t.Kind() {
+		case types.String:
+			return "s"
+		case types.Bool:
+			return "b"
+		case types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+			types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64, types.Uintptr,
+			types.Float32, types.Float64:
+			return "n"
+		default:
+			return typeWord(t)
+		}
+	case *types.Pointer:
+		return typeWord(t.Elem()) + "Ptr"
+	case *types.Slice:
+		return pluralize(typeWord(t.Elem()))
+	case *types.Array:
+		return pluralize(typeWord(t.Elem()))
+	case *types.Map:
+		return typeWord(t.Key()) + "To" + capitalize(typeWord(t.Elem()))
+	case *types.Chan:
+		return typeWord(t.Elem()) + "Ch"
+	case *types.Named:
+		return lowerFirst(t.Obj().Name())
+	default:
+		return "v"
+	}
+}
+
+// typeWord is the descriptive word used to build a composite name, as
+// opposed to the terse single-letter abbreviations deriveParamName uses
+// for a bare basic type.
+func typeWord(t types.Type) string {
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Name()
+	case *types.Named:
+		return lowerFirst(t.Obj().Name())
+	case *types.Pointer:
+		return typeWord(t.Elem()) + "Ptr"
+	case *types.Slice:
+		return pluralize(typeWord(t.Elem()))
+	default:
+		return "v"
+	}
+}
+
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+func capitalize(word string) string {
+	if word == "" {
+		return word
+	}
+
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+func lowerFirst(word string) string {
+	if word == "" {
+		return word
+	}
+
+	return strings.ToLower(word[:1]) + word[1:]
+}
@@ -0,0 +1,117 @@
+package registry_test
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emilien-puget/gomock/internal/registry"
+)
+
+func Test_MethodScope_Param(t *testing.T) {
+	fooPkg := types.NewPackage("example.com/foo", "foo")
+	fooNamed := types.NewNamed(types.NewTypeName(0, fooPkg, "Foo", nil), types.NewStruct(nil, nil), nil)
+
+	tests := map[string]struct {
+		explicit string
+		typ      types.Type
+		expected string
+	}{
+		"explicit name wins": {
+			explicit: "userID",
+			typ:      types.Typ[types.Int],
+			expected: "userID",
+		},
+		"string derives to s": {
+			typ:      types.Typ[types.String],
+			expected: "s",
+		},
+		"int derives to n": {
+			typ:      types.Typ[types.Int],
+			expected: "n",
+		},
+		"pointer derives Ptr suffix": {
+			typ:      types.NewPointer(fooNamed),
+			expected: "fooPtr",
+		},
+		"slice derives plural": {
+			typ:      types.NewSlice(fooNamed),
+			expected: "foos",
+		},
+		"map derives KeyToValue": {
+			typ:      types.NewMap(types.Typ[types.String], types.Typ[types.Int]),
+			expected: "stringToInt",
+		},
+		"chan derives Ch suffix": {
+			typ:      types.NewChan(types.SendRecv, types.Typ[types.Int]),
+			expected: "intCh",
+		},
+		"named derives lowerCamel": {
+			typ:      fooNamed,
+			expected: "foo",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scope := registry.New().NewMethodScope()
+			assert.Equal(t, tt.expected, scope.Param(tt.explicit, tt.typ))
+		})
+	}
+}
+
+func Test_MethodScope_Param_breaksTies(t *testing.T) {
+	scope := registry.New().NewMethodScope()
+
+	first := scope.Param("", types.Typ[types.String])
+	second := scope.Param("", types.Typ[types.String])
+
+	assert.Equal(t, "s", first)
+	assert.Equal(t, "s2", second)
+}
+
+func Test_MethodScope_Receiver(t *testing.T) {
+	t.Run("no collision keeps the preferred name", func(t *testing.T) {
+		scope := registry.New().NewMethodScope()
+		scope.Param("arg1", types.Typ[types.String])
+
+		assert.Equal(t, "m", scope.Receiver("m"))
+	})
+
+	t.Run("collision picks an alternative", func(t *testing.T) {
+		scope := registry.New().NewMethodScope()
+		scope.Param("m", types.Typ[types.String])
+
+		assert.Equal(t, "mReceiver", scope.Receiver("m"))
+	})
+}
+
+func Test_Registry_Alias(t *testing.T) {
+	t.Run("first use keeps the package name", func(t *testing.T) {
+		reg := registry.New()
+		pkg := types.NewPackage("example.com/foo", "foo")
+
+		assert.Equal(t, "foo", reg.Alias(pkg))
+		assert.Equal(t, []string{`"example.com/foo"`}, reg.Lines())
+	})
+
+	t.Run("same-basename packages disambiguate via parent directory", func(t *testing.T) {
+		reg := registry.New()
+		corev1 := types.NewPackage("k8s.io/api/core/v1", "v1")
+		appsv1 := types.NewPackage("k8s.io/api/apps/v1", "v1")
+
+		assert.Equal(t, "v1", reg.Alias(corev1))
+
+		// Seeing appsv1 collide with the still-bare "v1" retroactively
+		// qualifies corev1 too, so a caller that saw corev1 before the
+		// collision must re-query Alias to get its final identifier.
+		assert.Equal(t, "appsv1", reg.Alias(appsv1))
+		assert.Equal(t, "corev1", reg.Alias(corev1))
+
+		assert.Equal(t, []string{
+			`corev1 "k8s.io/api/core/v1"`,
+			`appsv1 "k8s.io/api/apps/v1"`,
+		}, reg.Lines())
+	})
+}
@@ -0,0 +1,131 @@
+// Package render turns go/types values into the Go source text used in
+// generated mocks.
+package render
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/emilien-puget/gomock/internal/registry"
+)
+
+// TypeString renders t as Go source, qualifying named types through
+// reg so that every reference shares the same alias as the file's
+// import block.
+func TypeString(t types.Type, reg *registry.Registry) string {
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.String()
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() == nil {
+			return obj.Name()
+		}
+
+		return reg.Alias(obj.Pkg()) + "." + obj.Name()
+	case *types.TypeParam:
+		return t.Obj().Name()
+	case *types.Pointer:
+		return "*" + TypeString(t.Elem(), reg)
+	case *types.Slice:
+		return "[]" + TypeString(t.Elem(), reg)
+	case *types.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), TypeString(t.Elem(), reg))
+	case *types.Map:
+		return fmt.Sprintf("map[%s]%s", TypeString(t.Key(), reg), TypeString(t.Elem(), reg))
+	case *types.Chan:
+		switch t.Dir() {
+		case types.SendOnly:
+			return "chan<- " + TypeString(t.Elem(), reg)
+		case types.RecvOnly:
+			return "<-chan " + TypeString(t.Elem(), reg)
+		default:
+			return "chan " + TypeString(t.Elem(), reg)
+		}
+	case *types.Signature:
+		return signatureString(t, reg)
+	case *types.Interface:
+		if t.Empty() {
+			return "any"
+		}
+
+		return interfaceString(t, reg)
+	case *types.Struct:
+		return structString(t, reg)
+	default:
+		return t.String()
+	}
+}
+
+// paramsString renders a tuple of parameters or results as a
+// comma-separated list, rendering the last entry as a variadic "...T"
+// when variadic is true.
+func paramsString(tuple *types.Tuple, variadic bool, reg *registry.Registry) string {
+	parts := make([]string, tuple.Len())
+	for i := 0; i < tuple.Len(); i++ {
+		typ := tuple.At(i).Type()
+		if variadic && i == tuple.Len()-1 {
+			if sl, ok := typ.(*types.Slice); ok {
+				parts[i] = "..." + TypeString(sl.Elem(), reg)
+				continue
+			}
+		}
+		parts[i] = TypeString(typ, reg)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func signatureString(sig *types.Signature, reg *registry.Registry) string {
+	params := paramsString(sig.Params(), sig.Variadic(), reg)
+	results := paramsString(sig.Results(), false, reg)
+
+	switch sig.Results().Len() {
+	case 0:
+		return fmt.Sprintf("func(%s)", params)
+	case 1:
+		return fmt.Sprintf("func(%s) %s", params, results)
+	default:
+		return fmt.Sprintf("func(%s) (%s)", params, results)
+	}
+}
+
+func interfaceString(iface *types.Interface, reg *registry.Registry) string {
+	if iface.NumMethods() == 0 {
+		// No explicit methods but not Empty() either: this is a
+		// structural constraint (comparable, a union like
+		// ~int | ~string, ...), rendered with go/types' own
+		// formatting. A named type inside such a constraint is
+		// printed with its full package path and is not registered
+		// with reg.
+		return iface.String()
+	}
+
+	methods := make([]string, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		m := iface.Method(i)
+		sig, _ := m.Type().(*types.Signature)
+		methods[i] = m.Name() + strings.TrimPrefix(signatureString(sig, reg), "func")
+	}
+
+	return "interface{ " + strings.Join(methods, "; ") + " }"
+}
+
+func structString(st *types.Struct, reg *registry.Registry) string {
+	if st.NumFields() == 0 {
+		return "struct{}"
+	}
+
+	fields := make([]string, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Embedded() {
+			fields[i] = TypeString(f.Type(), reg)
+			continue
+		}
+		fields[i] = f.Name() + " " + TypeString(f.Type(), reg)
+	}
+
+	return "struct{ " + strings.Join(fields, "; ") + " }"
+}
@@ -0,0 +1,133 @@
+package render_test
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emilien-puget/gomock/internal/registry"
+	"github.com/emilien-puget/gomock/internal/render"
+)
+
+func Test_TypeString(t *testing.T) {
+	fooPkg := types.NewPackage("example.com/foo", "foo")
+	fooNamed := types.NewNamed(types.NewTypeName(0, fooPkg, "Foo", nil), types.NewStruct(nil, nil), nil)
+
+	ctxPkg := types.NewPackage("context", "context")
+	ctxNamed := types.NewNamed(types.NewTypeName(0, ctxPkg, "Context", nil), types.NewInterfaceType(nil, nil).Complete(), nil)
+
+	errType := types.Universe.Lookup("error").Type()
+
+	tests := map[string]struct {
+		typ      func() types.Type
+		expected string
+	}{
+		"basic": {
+			typ:      func() types.Type { return types.Typ[types.String] },
+			expected: "string",
+		},
+		"pointer": {
+			typ:      func() types.Type { return types.NewPointer(fooNamed) },
+			expected: "*foo.Foo",
+		},
+		"pointer to pointer": {
+			typ:      func() types.Type { return types.NewPointer(types.NewPointer(types.Typ[types.Int])) },
+			expected: "**int",
+		},
+		"slice": {
+			typ:      func() types.Type { return types.NewSlice(types.Typ[types.String]) },
+			expected: "[]string",
+		},
+		"array": {
+			typ:      func() types.Type { return types.NewArray(types.Typ[types.Byte], 4) },
+			expected: "[4]uint8",
+		},
+		"map": {
+			typ: func() types.Type {
+				return types.NewMap(types.Typ[types.String], types.Typ[types.Int])
+			},
+			expected: "map[string]int",
+		},
+		"chan send only": {
+			typ:      func() types.Type { return types.NewChan(types.SendOnly, types.Typ[types.Int]) },
+			expected: "chan<- int",
+		},
+		"chan recv only": {
+			typ:      func() types.Type { return types.NewChan(types.RecvOnly, types.Typ[types.Int]) },
+			expected: "<-chan int",
+		},
+		"chan bidirectional": {
+			typ:      func() types.Type { return types.NewChan(types.SendRecv, types.Typ[types.Int]) },
+			expected: "chan int",
+		},
+		"any": {
+			typ:      func() types.Type { return types.NewInterfaceType(nil, nil).Complete() },
+			expected: "any",
+		},
+		"error": {
+			typ:      func() types.Type { return errType },
+			expected: "error",
+		},
+		"struct": {
+			typ: func() types.Type {
+				return types.NewStruct([]*types.Var{
+					types.NewVar(0, nil, "Name", types.Typ[types.String]),
+				}, nil)
+			},
+			expected: "struct{ Name string }",
+		},
+		"func with unnamed params and results": {
+			typ: func() types.Type {
+				return types.NewSignatureType(nil, nil, nil,
+					types.NewTuple(types.NewVar(0, nil, "", ctxNamed)),
+					types.NewTuple(types.NewVar(0, nil, "", types.NewPointer(fooNamed)), types.NewVar(0, nil, "", errType)),
+					false,
+				)
+			},
+			expected: "func(context.Context) (*foo.Foo, error)",
+		},
+		"variadic func": {
+			typ: func() types.Type {
+				return types.NewSignatureType(nil, nil, nil,
+					types.NewTuple(types.NewVar(0, nil, "", types.NewSlice(types.Typ[types.String]))),
+					nil,
+					true,
+				)
+			},
+			expected: "func(...string)",
+		},
+		"type param": {
+			typ: func() types.Type {
+				obj := types.NewTypeName(0, nil, "V", nil)
+				return types.NewTypeParam(obj, types.NewInterfaceType(nil, nil).Complete())
+			},
+			expected: "V",
+		},
+		"comparable constraint": {
+			typ: func() types.Type {
+				comparable := types.Universe.Lookup("comparable").Type()
+				return types.NewInterfaceType(nil, []types.Type{comparable}).Complete()
+			},
+			expected: "interface{comparable}",
+		},
+		"union constraint": {
+			typ: func() types.Type {
+				union := types.NewUnion([]*types.Term{
+					types.NewTerm(true, types.Typ[types.Int]),
+					types.NewTerm(true, types.Typ[types.String]),
+				})
+
+				return types.NewInterfaceType(nil, []types.Type{union}).Complete()
+			},
+			expected: "interface{~int | ~string}",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := registry.New()
+			assert.Equal(t, tt.expected, render.TypeString(tt.typ(), reg))
+		})
+	}
+}